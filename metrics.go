@@ -0,0 +1,89 @@
+// Copyright 2018 Mathieu Lonjaret
+
+package gocron
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+type metricKey struct {
+	job    string
+	status string
+}
+
+// jobMetrics accumulates the counters exposed on /metrics. It lives on
+// Cron so every run, in either Interval or Schedule mode, feeds the same
+// endpoint.
+type jobMetrics struct {
+	mu            sync.Mutex
+	runsTotal     map[metricKey]uint64
+	durationSum   map[string]float64
+	durationCount map[string]uint64
+}
+
+func (c *Cron) recordMetrics(event JobEvent) {
+	c.metrics.mu.Lock()
+	defer c.metrics.mu.Unlock()
+	if c.metrics.runsTotal == nil {
+		c.metrics.runsTotal = make(map[metricKey]uint64)
+		c.metrics.durationSum = make(map[string]float64)
+		c.metrics.durationCount = make(map[string]uint64)
+	}
+	c.metrics.runsTotal[metricKey{job: event.JobName, status: event.Type.String()}]++
+	c.metrics.durationSum[event.JobName] += event.Duration.Seconds()
+	c.metrics.durationCount[event.JobName]++
+}
+
+// writeMetrics writes gocron_job_runs_total, gocron_job_duration_seconds,
+// gocron_last_success_timestamp_seconds and gocron_consecutive_failures in
+// the Prometheus text exposition format.
+func (c *Cron) writeMetrics(w io.Writer) {
+	c.metrics.mu.Lock()
+	defer c.metrics.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP gocron_job_runs_total Total number of job runs, by outcome.")
+	fmt.Fprintln(w, "# TYPE gocron_job_runs_total counter")
+	keys := make([]metricKey, 0, len(c.metrics.runsTotal))
+	for k := range c.metrics.runsTotal {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].job != keys[j].job {
+			return keys[i].job < keys[j].job
+		}
+		return keys[i].status < keys[j].status
+	})
+	for _, k := range keys {
+		fmt.Fprintf(w, "gocron_job_runs_total{job=%q,status=%q} %d\n", k.job, k.status, c.metrics.runsTotal[k])
+	}
+
+	// TODO(mpl): a real histogram with buckets would need client_golang,
+	// which this module doesn't vendor; _sum/_count is the honest subset
+	// we can expose by hand.
+	fmt.Fprintln(w, "# HELP gocron_job_duration_seconds Time spent running the job, including retries.")
+	fmt.Fprintln(w, "# TYPE gocron_job_duration_seconds summary")
+	jobs := make([]string, 0, len(c.metrics.durationCount))
+	for job := range c.metrics.durationCount {
+		jobs = append(jobs, job)
+	}
+	sort.Strings(jobs)
+	for _, job := range jobs {
+		fmt.Fprintf(w, "gocron_job_duration_seconds_sum{job=%q} %g\n", job, c.metrics.durationSum[job])
+		fmt.Fprintf(w, "gocron_job_duration_seconds_count{job=%q} %d\n", job, c.metrics.durationCount[job])
+	}
+
+	fmt.Fprintln(w, "# HELP gocron_last_success_timestamp_seconds Unix time of the last successful run.")
+	fmt.Fprintln(w, "# TYPE gocron_last_success_timestamp_seconds gauge")
+	if t, ok, err := c.store().LastSuccess(); err == nil && ok {
+		fmt.Fprintf(w, "gocron_last_success_timestamp_seconds %d\n", t.Unix())
+	}
+
+	fmt.Fprintln(w, "# HELP gocron_consecutive_failures Number of consecutive fully-failed runs.")
+	fmt.Fprintln(w, "# TYPE gocron_consecutive_failures gauge")
+	c.stateMu.Lock()
+	fmt.Fprintf(w, "gocron_consecutive_failures %d\n", c.consecFailures)
+	c.stateMu.Unlock()
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 Mathieu Lonjaret
+
+package gocron
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// RetryConfig configures retrying a failed run, with exponential backoff
+// and optional jitter (Jitter = 1 gives full jitter), before it is reported
+// as Failed. The zero value means
+// no retries: a single attempt.
+type RetryConfig struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64 // defaults to 2 if <= 0
+	Jitter         float64 // in [0,1]: fraction of the backoff that is randomized
+	// IsRetryable, if set, decides whether an error is worth retrying.
+	// By default every error is retried.
+	IsRetryable func(error) bool
+}
+
+func (r RetryConfig) withDefaults() RetryConfig {
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = 1
+	}
+	if r.InitialBackoff <= 0 {
+		r.InitialBackoff = time.Second
+	}
+	if r.MaxBackoff <= 0 {
+		r.MaxBackoff = r.InitialBackoff
+	}
+	if r.Multiplier <= 0 {
+		r.Multiplier = 2
+	}
+	return r
+}
+
+// runWithRetry runs c.runJob(ctx), retrying on failure per c.Retry, and
+// returns the error of the last attempt (nil if any attempt succeeded, or
+// ctx.Err() if ctx is done while waiting to retry), how many attempts were
+// made, and the total elapsed time including backoffs.
+func (c *Cron) runWithRetry(ctx context.Context) (jobErr error, attempts int, elapsed time.Duration) {
+	cfg := c.Retry.withDefaults()
+	start := time.Now()
+	backoff := cfg.InitialBackoff
+	for attempts = 1; attempts <= cfg.MaxAttempts; attempts++ {
+		jobErr = c.runJob(ctx)
+		if jobErr == nil {
+			break
+		}
+		if cfg.IsRetryable != nil && !cfg.IsRetryable(jobErr) {
+			break
+		}
+		if attempts == cfg.MaxAttempts {
+			break
+		}
+		wait := backoff
+		if cfg.Jitter > 0 {
+			wait = time.Duration(float64(wait) * (1 - cfg.Jitter + cfg.Jitter*rand.Float64()))
+		}
+		select {
+		case <-ctx.Done():
+			jobErr = ctx.Err()
+			return jobErr, attempts, time.Since(start)
+		case <-time.After(wait):
+		}
+		backoff = time.Duration(float64(backoff) * cfg.Multiplier)
+		if backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+	}
+	return jobErr, attempts, time.Since(start)
+}
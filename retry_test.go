@@ -0,0 +1,143 @@
+// Copyright 2018 Mathieu Lonjaret
+
+package gocron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunWithRetryZeroValueIsSingleAttempt(t *testing.T) {
+	calls := 0
+	c := &Cron{JobFunc: func(ctx context.Context) error {
+		calls++
+		return errors.New("boom")
+	}}
+	jobErr, attempts, _ := c.runWithRetry(context.Background())
+	if attempts != 1 || calls != 1 {
+		t.Errorf("attempts = %d, calls = %d, want 1, 1", attempts, calls)
+	}
+	if jobErr == nil {
+		t.Error("jobErr = nil, want the job's error")
+	}
+}
+
+func TestRunWithRetrySucceedsBeforeMaxAttempts(t *testing.T) {
+	calls := 0
+	c := &Cron{
+		JobFunc: func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		},
+		Retry: RetryConfig{
+			MaxAttempts:    5,
+			InitialBackoff: time.Millisecond,
+		},
+	}
+	jobErr, attempts, _ := c.runWithRetry(context.Background())
+	if jobErr != nil {
+		t.Errorf("jobErr = %v, want nil", jobErr)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRunWithRetryStopsAtMaxAttempts(t *testing.T) {
+	calls := 0
+	c := &Cron{
+		JobFunc: func(ctx context.Context) error {
+			calls++
+			return errors.New("always fails")
+		},
+		Retry: RetryConfig{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+		},
+	}
+	jobErr, attempts, _ := c.runWithRetry(context.Background())
+	if jobErr == nil {
+		t.Error("jobErr = nil, want an error after every attempt failed")
+	}
+	if attempts != 3 || calls != 3 {
+		t.Errorf("attempts = %d, calls = %d, want 3, 3", attempts, calls)
+	}
+}
+
+func TestRunWithRetryHonorsIsRetryable(t *testing.T) {
+	errFatal := errors.New("fatal")
+	calls := 0
+	c := &Cron{
+		JobFunc: func(ctx context.Context) error {
+			calls++
+			return errFatal
+		},
+		Retry: RetryConfig{
+			MaxAttempts:    5,
+			InitialBackoff: time.Millisecond,
+			IsRetryable: func(err error) bool {
+				return err != errFatal
+			},
+		},
+	}
+	jobErr, attempts, _ := c.runWithRetry(context.Background())
+	if jobErr != errFatal {
+		t.Errorf("jobErr = %v, want errFatal", jobErr)
+	}
+	if attempts != 1 || calls != 1 {
+		t.Errorf("attempts = %d, calls = %d, want 1, 1 (non-retryable error)", attempts, calls)
+	}
+}
+
+func TestRunWithRetryBacksOffBetweenAttempts(t *testing.T) {
+	calls := 0
+	c := &Cron{
+		JobFunc: func(ctx context.Context) error {
+			calls++
+			return errors.New("boom")
+		},
+		Retry: RetryConfig{
+			MaxAttempts:    3,
+			InitialBackoff: 20 * time.Millisecond,
+			MaxBackoff:     100 * time.Millisecond,
+			Multiplier:     2,
+		},
+	}
+	start := time.Now()
+	c.runWithRetry(context.Background())
+	elapsed := time.Since(start)
+	// Two backoffs of 20ms and 40ms between the 3 attempts: at least 60ms.
+	if elapsed < 60*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least 60ms for a 20ms/40ms backoff sequence", elapsed)
+	}
+}
+
+func TestRunWithRetryStopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	c := &Cron{
+		JobFunc: func(ctx context.Context) error {
+			calls++
+			if calls == 1 {
+				cancel()
+			}
+			return errors.New("boom")
+		},
+		Retry: RetryConfig{
+			MaxAttempts:    5,
+			InitialBackoff: time.Second,
+		},
+	}
+	jobErr, attempts, _ := c.runWithRetry(ctx)
+	if jobErr != context.Canceled {
+		t.Errorf("jobErr = %v, want context.Canceled", jobErr)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (canceled while waiting to retry)", attempts)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2018 Mathieu Lonjaret
+
+package gocron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression, in the standard five-field format:
+// minute hour day-of-month month day-of-week. Each field accepts "*", a
+// single value, a comma-separated list, a range ("a-b"), or a step ("*/n"
+// or "a-b/n").
+type Schedule struct {
+	minute, hour, dom, month, dow fieldSet
+	// domStar and dowStar record whether the day-of-month/day-of-week
+	// fields were literally "*", i.e. unrestricted. Standard cron ORs dom
+	// and dow instead of ANDing them when both are restricted; see Next.
+	domStar, dowStar bool
+}
+
+// fieldSet is the set of values a cron field is allowed to match.
+type fieldSet map[int]bool
+
+var fieldRanges = [5]struct{ min, max int }{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 6},  // day of week, 0 = Sunday
+}
+
+// ParseSchedule parses expr as a standard five-field cron expression.
+func ParseSchedule(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("gocron: cron expression %q: want 5 fields, got %d", expr, len(fields))
+	}
+	var sets [5]fieldSet
+	for i, f := range fields {
+		set, err := parseField(f, fieldRanges[i].min, fieldRanges[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("gocron: cron expression %q: field %d: %v", expr, i+1, err)
+		}
+		sets[i] = set
+	}
+	return &Schedule{
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}, nil
+}
+
+func parseField(f string, min, max int) (fieldSet, error) {
+	set := make(fieldSet)
+	for _, part := range strings.Split(f, ",") {
+		if err := parsePart(set, part, min, max); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+func parsePart(set fieldSet, part string, min, max int) error {
+	step := 1
+	if i := strings.Index(part, "/"); i != -1 {
+		n, err := strconv.Atoi(part[i+1:])
+		if err != nil {
+			return fmt.Errorf("invalid step in %q: %v", part, err)
+		}
+		if n <= 0 {
+			return fmt.Errorf("invalid step in %q: must be positive", part)
+		}
+		step = n
+		part = part[:i]
+	}
+	lo, hi := min, max
+	switch {
+	case part == "*":
+		// lo, hi already cover the whole field.
+	case strings.Contains(part, "-"):
+		bounds := strings.SplitN(part, "-", 2)
+		a, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return fmt.Errorf("invalid range %q: %v", part, err)
+		}
+		b, err := strconv.Atoi(bounds[1])
+		if err != nil {
+			return fmt.Errorf("invalid range %q: %v", part, err)
+		}
+		lo, hi = a, b
+	default:
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("invalid value %q: %v", part, err)
+		}
+		lo, hi = n, n
+	}
+	if lo < min || hi > max {
+		return fmt.Errorf("value out of range [%d,%d] in %q", min, max, part)
+	}
+	for v := lo; v <= hi; v += step {
+		set[v] = true
+	}
+	return nil
+}
+
+// Next returns the next minute-aligned time strictly after t at which s
+// fires.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// A schedule should always fire again within four years (covers leap
+	// years); bail out rather than loop forever on a field set that can
+	// never match (e.g. Feb 30th).
+	for limit := 0; limit < 4*366*24*60; limit++ {
+		if s.month[int(t.Month())] && s.dayMatches(t) &&
+			s.hour[t.Hour()] && s.minute[t.Minute()] {
+			return t
+		}
+		t = t.Add(time.Minute)
+	}
+	return t
+}
+
+// dayMatches applies the standard (POSIX) cron rule for combining
+// day-of-month and day-of-week: when both fields are restricted (neither is
+// "*"), a match on either one fires, rather than requiring both.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom, dow := s.dom[t.Day()], s.dow[int(t.Weekday())]
+	if !s.domStar && !s.dowStar {
+		return dom || dow
+	}
+	return dom && dow
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 Mathieu Lonjaret
+
+package gocron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleRejectsNonPositiveStep(t *testing.T) {
+	for _, expr := range []string{
+		"*/0 * * * *",
+		"*/-1 * * * *",
+	} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Errorf("ParseSchedule(%q): want error, got nil", expr)
+		}
+	}
+}
+
+func TestParseScheduleRejectsWrongFieldCount(t *testing.T) {
+	if _, err := ParseSchedule("* * * *"); err == nil {
+		t.Error("ParseSchedule with 4 fields: want error, got nil")
+	}
+}
+
+func mustParseSchedule(t *testing.T, expr string) *Schedule {
+	t.Helper()
+	s, err := ParseSchedule(expr)
+	if err != nil {
+		t.Fatalf("ParseSchedule(%q): %v", expr, err)
+	}
+	return s
+}
+
+func TestScheduleNextEveryMinute(t *testing.T) {
+	s := mustParseSchedule(t, "* * * * *")
+	from := time.Date(2020, 1, 1, 12, 0, 30, 0, time.UTC)
+	got := s.Next(from)
+	want := time.Date(2020, 1, 1, 12, 1, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", from, got, want)
+	}
+}
+
+func TestScheduleNextDailyAtHour(t *testing.T) {
+	s := mustParseSchedule(t, "0 8 * * *")
+	from := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	got := s.Next(from)
+	want := time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", from, got, want)
+	}
+}
+
+func TestScheduleNextStepAndWeekday(t *testing.T) {
+	// Weekdays only, every 15 minutes past 9.
+	s := mustParseSchedule(t, "*/15 9 * * 1-5")
+	// 2020-01-04 is a Saturday; next weekday is Monday 2020-01-06.
+	from := time.Date(2020, 1, 4, 9, 0, 0, 0, time.UTC)
+	got := s.Next(from)
+	want := time.Date(2020, 1, 6, 9, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", from, got, want)
+	}
+}
+
+func TestScheduleNextDomDowOrWhenBothRestricted(t *testing.T) {
+	// Standard cron: when both dom and dow are restricted, a match on
+	// either fires. 2022-01-01 was a Saturday, which 1-5 excludes, but
+	// day 1 matches the dom field, so it should still fire.
+	s := mustParseSchedule(t, "0 8 1 * 1-5")
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := s.Next(from)
+	want := time.Date(2022, 1, 1, 8, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v (dom should OR with dow)", from, got, want)
+	}
+}
+
+func TestScheduleNextDomDowAndWhenOneIsStar(t *testing.T) {
+	// dow is "*" (unrestricted), so this degrades to a plain dom match:
+	// only the 2nd of the month at 08:00.
+	s := mustParseSchedule(t, "0 8 2 * *")
+	from := time.Date(2022, 1, 1, 9, 0, 0, 0, time.UTC)
+	got := s.Next(from)
+	want := time.Date(2022, 1, 2, 8, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", from, got, want)
+	}
+}